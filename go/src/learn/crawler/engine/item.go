@@ -0,0 +1,15 @@
+package engine
+
+// Item is the unit of work produced by a crawler Parser and consumed by
+// an ItemSaver.
+type Item struct {
+	Url  string
+	Id   string
+	Type string
+	// Payload carries parser-specific data, e.g. model.Profile.
+	Payload interface{}
+	// CrawlerId holds the original crawler-assigned Id when the saver
+	// has replaced Id with a generated value (see
+	// rpcsupport.ItemSaverService.UseUUID).
+	CrawlerId string `json:"crawlerId,omitempty"`
+}
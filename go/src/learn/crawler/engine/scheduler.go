@@ -0,0 +1,45 @@
+package engine
+
+// Deduper reports and records whether a URL has already been crawled,
+// so a Scheduler can skip fetch tasks for URLs some other worker (or an
+// earlier run) already handled. rpcsupport.DedupClient implements this
+// against the distributed DedupService.
+type Deduper interface {
+	Seen(url string) (bool, error)
+	Mark(url string) error
+}
+
+// FetchFunc dispatches a single fetch task for url.
+type FetchFunc func(url string) error
+
+// Scheduler dispatches fetch tasks for URLs, consulting Dedup before
+// every dispatch so the same URL isn't fetched twice across workers.
+type Scheduler struct {
+	Dedup Deduper
+	Fetch FetchFunc
+}
+
+// NewScheduler builds a Scheduler that checks dedup before fetching
+// and marks each dispatched URL seen.
+func NewScheduler(dedup Deduper, fetch FetchFunc) *Scheduler {
+	return &Scheduler{Dedup: dedup, Fetch: fetch}
+}
+
+// Schedule consults Dedup for url. If url was already seen, Schedule
+// skips it without calling Fetch. Otherwise it marks url seen before
+// dispatching Fetch, so a later Schedule call for the same url skips
+// it. Seen and Mark are separate calls: two calls for the same url
+// that both check Seen before either marks it both dispatch Fetch.
+func (s *Scheduler) Schedule(url string) error {
+	seen, err := s.Dedup.Seen(url)
+	if err != nil {
+		return err
+	}
+	if seen {
+		return nil
+	}
+	if err := s.Dedup.Mark(url); err != nil {
+		return err
+	}
+	return s.Fetch(url)
+}
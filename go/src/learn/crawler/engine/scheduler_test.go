@@ -0,0 +1,55 @@
+package engine
+
+import "testing"
+
+type fakeDeduper struct {
+	seen   map[string]bool
+	marked []string
+}
+
+func (d *fakeDeduper) Seen(url string) (bool, error) {
+	return d.seen[url], nil
+}
+
+func (d *fakeDeduper) Mark(url string) error {
+	d.marked = append(d.marked, url)
+	return nil
+}
+
+func TestScheduleSkipsSeenURL(t *testing.T) {
+	dedup := &fakeDeduper{seen: map[string]bool{"a": true}}
+	fetched := false
+	s := NewScheduler(dedup, func(url string) error {
+		fetched = true
+		return nil
+	})
+
+	if err := s.Schedule("a"); err != nil {
+		t.Fatalf("Schedule returned %v", err)
+	}
+	if fetched {
+		t.Error("Schedule fetched a URL already marked seen")
+	}
+	if len(dedup.marked) != 0 {
+		t.Errorf("Schedule marked %v for a URL already seen", dedup.marked)
+	}
+}
+
+func TestScheduleMarksAndFetchesNewURL(t *testing.T) {
+	dedup := &fakeDeduper{seen: map[string]bool{}}
+	var fetched string
+	s := NewScheduler(dedup, func(url string) error {
+		fetched = url
+		return nil
+	})
+
+	if err := s.Schedule("b"); err != nil {
+		t.Fatalf("Schedule returned %v", err)
+	}
+	if fetched != "b" {
+		t.Errorf("Schedule fetched %q, want %q", fetched, "b")
+	}
+	if len(dedup.marked) != 1 || dedup.marked[0] != "b" {
+		t.Errorf("Schedule marked %v, want [b]", dedup.marked)
+	}
+}
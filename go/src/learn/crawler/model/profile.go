@@ -0,0 +1,45 @@
+package model
+
+import (
+	"encoding/json"
+
+	"gopkg.in/olivere/elastic.v3"
+)
+
+// Profile is the structured data extracted from a dating-site profile
+// page.
+type Profile struct {
+	Name       string
+	Occupation string
+	Hukou      string
+	Age        int
+	Height     int
+	Income     string
+
+	// Suggest feeds the completion suggester so profiles can be
+	// autocompleted by name/occupation/hukou; it is nil until a caller
+	// assigns it the result of BuildSuggest.
+	Suggest *elastic.SuggestField `json:"suggest,omitempty"`
+}
+
+// BuildSuggest assembles the completion-suggester input/output/payload
+// for this profile: inputs are its non-empty Name/Occupation/Hukou, the
+// output is its Name, and the payload carries url/id so a suggestion
+// can be resolved back to the profile that produced it.
+func (p Profile) BuildSuggest(url, id string) *elastic.SuggestField {
+	payload, _ := json.Marshal(map[string]string{"url": url, "id": id})
+	return elastic.NewSuggestField().
+		Input(p.suggestInputs()...).
+		Output(p.Name).
+		Payload(payload)
+}
+
+func (p Profile) suggestInputs() []string {
+	inputs := make([]string, 0, 3)
+	for _, v := range []string{p.Name, p.Occupation, p.Hukou} {
+		if v != "" {
+			inputs = append(inputs, v)
+		}
+	}
+	return inputs
+}
@@ -0,0 +1,29 @@
+// Package backend defines the Storage abstraction ItemSaverService
+// depends on, so the saver can be backed by different Elasticsearch
+// client generations without changing its own code.
+package backend
+
+import (
+	"context"
+
+	"learn/crawler/engine"
+)
+
+// Storage is the persistence contract ItemSaverService relies on.
+type Storage interface {
+	Save(ctx context.Context, item engine.Item) (id string, err error)
+	Get(ctx context.Context, typ, id string) (engine.Item, error)
+	Delete(ctx context.Context, typ, id string) error
+	BulkSave(ctx context.Context, items []engine.Item) error
+	HealthCheck(ctx context.Context) error
+	Close() error
+}
+
+// AsyncBulkStorage is implemented by backends that can batch writes and
+// report per-item completion asynchronously, mirroring olivere's
+// BulkProcessor. ItemSaverService uses it when available and falls
+// back to synchronous Storage.Save otherwise.
+type AsyncBulkStorage interface {
+	Storage
+	SaveAsync(item engine.Item, done func(error))
+}
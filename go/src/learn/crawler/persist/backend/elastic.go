@@ -0,0 +1,269 @@
+package backend
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"gopkg.in/olivere/elastic.v3"
+
+	"learn/crawler/engine"
+	"learn/crawler/persist"
+	"learn/crawler/persist/config"
+)
+
+// Bulk processor tuning defaults. The flush and worker settings can be
+// overridden via BulkConfig; the backoff delays are fixed.
+const (
+	defaultFlushActions  = 500
+	defaultFlushBytes    = 5 << 20 // 5MB
+	defaultFlushInterval = 5 * time.Second
+	defaultWorkers       = 2
+	defaultInitialDelay  = 100 * time.Millisecond
+	defaultMaxDelay      = 8 * time.Second
+)
+
+// BulkConfig tunes the elastic.BulkProcessor backing ElasticBackend.
+// Zero values fall back to the defaults above.
+type BulkConfig struct {
+	FlushActions  int
+	FlushBytes    int64
+	FlushInterval time.Duration
+	Workers       int
+}
+
+// ElasticBackend implements Storage (and AsyncBulkStorage) on top of
+// the olivere v3 client, batching writes through an
+// elastic.BulkProcessor.
+type ElasticBackend struct {
+	client *elastic.Client
+	index  string
+
+	mu sync.Mutex
+	// pending queues one completion channel per in-flight SaveAsync
+	// call for a doc id, FIFO: concurrent saves/retries of the same id
+	// (e.g. re-crawling a profile) must not clobber each other's
+	// channel, so each call gets its own waiter in line rather than a
+	// single slot keyed by id.
+	pending   map[string][]chan error
+	processor *elastic.BulkProcessor
+}
+
+// NewElasticBackend builds an ElasticBackend, ensures its backing
+// index/mapping exists via persist.IndexManager, and starts its bulk
+// processor.
+func NewElasticBackend(cfg config.Config, index string, bulkCfg BulkConfig) (*ElasticBackend, error) {
+	opts := []elastic.ClientOptionFunc{
+		elastic.SetURL(cfg.URLs...),
+		elastic.SetSniff(cfg.SniffEnabled),
+	}
+	if cfg.BasicAuthUsername != "" {
+		opts = append(opts, elastic.SetBasicAuth(cfg.BasicAuthUsername, cfg.BasicAuthPassword))
+	}
+	if cfg.HealthcheckInterval > 0 {
+		opts = append(opts, elastic.SetHealthcheckInterval(cfg.HealthcheckInterval))
+	}
+	if cfg.ErrorLog != nil {
+		opts = append(opts, elastic.SetErrorLog(cfg.ErrorLog))
+	}
+	if cfg.TLSInsecureSkipVerify {
+		opts = append(opts, elastic.SetHttpClient(&http.Client{
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+			},
+		}))
+	}
+
+	client, err := elastic.NewClient(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	manager, err := persist.NewIndexManager(client, index)
+	if err != nil {
+		return nil, err
+	}
+	if err := manager.EnsureIndex(); err != nil {
+		return nil, err
+	}
+
+	if bulkCfg.FlushActions <= 0 {
+		bulkCfg.FlushActions = defaultFlushActions
+	}
+	if bulkCfg.FlushBytes <= 0 {
+		bulkCfg.FlushBytes = defaultFlushBytes
+	}
+	if bulkCfg.FlushInterval <= 0 {
+		bulkCfg.FlushInterval = defaultFlushInterval
+	}
+	if bulkCfg.Workers <= 0 {
+		bulkCfg.Workers = defaultWorkers
+	}
+
+	b := &ElasticBackend{
+		client:  client,
+		index:   index,
+		pending: make(map[string][]chan error),
+	}
+
+	processor, err := client.BulkProcessor().
+		Name("item-saver").
+		Workers(bulkCfg.Workers).
+		BulkActions(bulkCfg.FlushActions).
+		BulkSize(int(bulkCfg.FlushBytes)).
+		FlushInterval(bulkCfg.FlushInterval).
+		Backoff(elastic.NewExponentialBackoff(defaultInitialDelay, defaultMaxDelay)).
+		After(b.afterBulk).
+		Do()
+	if err != nil {
+		return nil, err
+	}
+	b.processor = processor
+
+	return b, nil
+}
+
+// Save indexes item synchronously, bypassing the bulk processor.
+func (b *ElasticBackend) Save(ctx context.Context, item engine.Item) (string, error) {
+	_, err := b.client.Index().
+		Index(b.index).
+		Type(item.Type).
+		Id(item.Id).
+		BodyJson(item).
+		Do()
+	if err != nil {
+		return "", err
+	}
+	return item.Id, nil
+}
+
+// SaveAsync buffers item for bulk indexing and invokes done once that
+// item's own bulk response comes back.
+func (b *ElasticBackend) SaveAsync(item engine.Item, done func(error)) {
+	ch := make(chan error, 1)
+	b.mu.Lock()
+	b.pending[item.Id] = append(b.pending[item.Id], ch)
+	b.mu.Unlock()
+
+	req := elastic.NewBulkIndexRequest().
+		Index(b.index).
+		Type(item.Type).
+		Id(item.Id).
+		Doc(item)
+	b.processor.Add(req)
+
+	go func() { done(<-ch) }()
+}
+
+// resolveOnePending delivers err to the oldest still-waiting SaveAsync
+// call for id, if any, and dequeues it. Callers must hold b.mu.
+func (b *ElasticBackend) resolveOnePending(id string, err error) {
+	waiters := b.pending[id]
+	if len(waiters) == 0 {
+		return
+	}
+	waiters[0] <- err
+	if len(waiters) == 1 {
+		delete(b.pending, id)
+	} else {
+		b.pending[id] = waiters[1:]
+	}
+}
+
+// afterBulk is invoked by the BulkProcessor after every flush; it
+// resolves one pending waiter per doc ID in the response, regardless of
+// whether that particular item succeeded.
+func (b *ElasticBackend) afterBulk(executionId int64, requests []elastic.BulkableRequest, response *elastic.BulkResponse, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if response == nil {
+		// The whole flush failed before a response was received (e.g.
+		// retries exhausted). Only fail the doc IDs this flush owned:
+		// defaultWorkers allows a second flush to be in-flight
+		// concurrently, sharing b.pending, and sweeping every waiter
+		// for an id would fail its still-pending (possibly successful)
+		// saves too.
+		for _, id := range bulkRequestIds(requests) {
+			b.resolveOnePending(id, err)
+		}
+		return
+	}
+
+	for _, items := range response.Items {
+		for _, item := range items {
+			if item.Error != nil {
+				b.resolveOnePending(item.Id, fmt.Errorf("bulk index %s failed: %s", item.Id, item.Error.Reason))
+			} else {
+				b.resolveOnePending(item.Id, nil)
+			}
+		}
+	}
+}
+
+// bulkRequestIds extracts the doc IDs a flush's requests were indexing,
+// by parsing the action/metadata line each BulkableRequest.Source()
+// emits (e.g. {"index":{"_id":"...",...}}).
+func bulkRequestIds(requests []elastic.BulkableRequest) []string {
+	ids := make([]string, 0, len(requests))
+	for _, req := range requests {
+		lines, err := req.Source()
+		if err != nil || len(lines) == 0 {
+			continue
+		}
+		var action map[string]struct {
+			Id string `json:"_id"`
+		}
+		if err := json.Unmarshal([]byte(lines[0]), &action); err != nil {
+			continue
+		}
+		for _, meta := range action {
+			if meta.Id != "" {
+				ids = append(ids, meta.Id)
+			}
+		}
+	}
+	return ids
+}
+
+func (b *ElasticBackend) Get(ctx context.Context, typ, id string) (engine.Item, error) {
+	var item engine.Item
+	result, err := b.client.Get().Index(b.index).Type(typ).Id(id).Do()
+	if err != nil {
+		return item, err
+	}
+	err = json.Unmarshal(*result.Source, &item)
+	return item, err
+}
+
+func (b *ElasticBackend) Delete(ctx context.Context, typ, id string) error {
+	_, err := b.client.Delete().Index(b.index).Type(typ).Id(id).Do()
+	return err
+}
+
+func (b *ElasticBackend) BulkSave(ctx context.Context, items []engine.Item) error {
+	bulk := b.client.Bulk()
+	for _, item := range items {
+		bulk.Add(elastic.NewBulkIndexRequest().
+			Index(b.index).Type(item.Type).Id(item.Id).Doc(item))
+	}
+	_, err := bulk.Do()
+	return err
+}
+
+func (b *ElasticBackend) HealthCheck(ctx context.Context) error {
+	_, err := b.client.ClusterHealth().Do()
+	return err
+}
+
+// Close flushes any buffered items, stops the bulk processor, and stops
+// the underlying client.
+func (b *ElasticBackend) Close() error {
+	err := b.processor.Close()
+	b.client.Stop()
+	return err
+}
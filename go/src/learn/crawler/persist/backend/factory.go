@@ -0,0 +1,19 @@
+package backend
+
+import (
+	"fmt"
+
+	"learn/crawler/persist/config"
+)
+
+// New builds the Storage implementation selected by cfg.Backend.
+func New(cfg config.Config, index string) (Storage, error) {
+	switch cfg.Backend {
+	case "elastic":
+		return NewElasticBackend(cfg, index, BulkConfig{})
+	case "opensearch":
+		return NewOpenSearchBackend(cfg, index)
+	default:
+		return nil, fmt.Errorf("backend: unknown backend %q", cfg.Backend)
+	}
+}
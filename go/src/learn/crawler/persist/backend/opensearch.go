@@ -0,0 +1,197 @@
+package backend
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"learn/crawler/engine"
+	"learn/crawler/persist"
+	"learn/crawler/persist/config"
+)
+
+// OpenSearchBackend implements Storage against the plain Elasticsearch/
+// OpenSearch REST API, so this module isn't pinned to the olivere v3
+// client once a cluster moves past the versions it supports.
+type OpenSearchBackend struct {
+	httpClient *http.Client
+	baseURL    string
+	index      string
+	username   string
+	password   string
+}
+
+// NewOpenSearchBackend builds an OpenSearchBackend and ensures its
+// backing index/mapping exists.
+func NewOpenSearchBackend(cfg config.Config, index string) (*OpenSearchBackend, error) {
+	if len(cfg.URLs) == 0 {
+		return nil, fmt.Errorf("backend: opensearch config requires at least one URL")
+	}
+
+	httpClient := &http.Client{}
+	if cfg.TLSInsecureSkipVerify {
+		httpClient.Transport = &http.Transport{
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		}
+	}
+
+	b := &OpenSearchBackend{
+		httpClient: httpClient,
+		baseURL:    strings.TrimRight(cfg.URLs[0], "/"),
+		index:      index,
+		username:   cfg.BasicAuthUsername,
+		password:   cfg.BasicAuthPassword,
+	}
+
+	if err := b.ensureIndex(context.Background()); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// ensureIndex creates b.index with persist.ProfileProperties if it
+// doesn't already exist.
+func (b *OpenSearchBackend) ensureIndex(ctx context.Context) error {
+	resp, err := b.do(ctx, http.MethodHead, b.baseURL+"/"+b.index, nil)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	resp, err = b.do(ctx, http.MethodPut, b.baseURL+"/"+b.index, map[string]interface{}{
+		"mappings": persist.ProfileProperties(),
+	})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("backend: create index %s returned %s", b.index, resp.Status)
+	}
+	return nil
+}
+
+func (b *OpenSearchBackend) docURL(typ, id string) string {
+	return fmt.Sprintf("%s/%s/_doc/%s", b.baseURL, b.index, id)
+}
+
+func (b *OpenSearchBackend) do(ctx context.Context, method, url string, body interface{}) (*http.Response, error) {
+	var reader *bytes.Reader
+	if body != nil {
+		raw, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewReader(raw)
+	} else {
+		reader = bytes.NewReader(nil)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, reader)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if b.username != "" {
+		req.SetBasicAuth(b.username, b.password)
+	}
+	return b.httpClient.Do(req)
+}
+
+func (b *OpenSearchBackend) Save(ctx context.Context, item engine.Item) (string, error) {
+	resp, err := b.do(ctx, http.MethodPut, b.docURL(item.Type, item.Id), item)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return "", fmt.Errorf("backend: save %s returned %s", item.Id, resp.Status)
+	}
+	return item.Id, nil
+}
+
+func (b *OpenSearchBackend) Get(ctx context.Context, typ, id string) (engine.Item, error) {
+	var out struct {
+		Source engine.Item `json:"_source"`
+	}
+	resp, err := b.do(ctx, http.MethodGet, b.docURL(typ, id), nil)
+	if err != nil {
+		return engine.Item{}, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return engine.Item{}, fmt.Errorf("backend: get %s returned %s", id, resp.Status)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return engine.Item{}, err
+	}
+	return out.Source, nil
+}
+
+func (b *OpenSearchBackend) Delete(ctx context.Context, typ, id string) error {
+	resp, err := b.do(ctx, http.MethodDelete, b.docURL(typ, id), nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 && resp.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("backend: delete %s returned %s", id, resp.Status)
+	}
+	return nil
+}
+
+func (b *OpenSearchBackend) BulkSave(ctx context.Context, items []engine.Item) error {
+	var buf bytes.Buffer
+	for _, item := range items {
+		action := map[string]interface{}{
+			"index": map[string]string{"_index": b.index, "_type": item.Type, "_id": item.Id},
+		}
+		actionLine, _ := json.Marshal(action)
+		docLine, _ := json.Marshal(item)
+		buf.Write(actionLine)
+		buf.WriteByte('\n')
+		buf.Write(docLine)
+		buf.WriteByte('\n')
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, b.baseURL+"/_bulk", &buf)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	if b.username != "" {
+		req.SetBasicAuth(b.username, b.password)
+	}
+	resp, err := b.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("backend: bulk save returned %s", resp.Status)
+	}
+	return nil
+}
+
+func (b *OpenSearchBackend) HealthCheck(ctx context.Context) error {
+	resp, err := b.do(ctx, http.MethodGet, b.baseURL+"/_cluster/health", nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("backend: health check returned %s", resp.Status)
+	}
+	return nil
+}
+
+func (b *OpenSearchBackend) Close() error {
+	return nil
+}
@@ -0,0 +1,26 @@
+// Package config describes the connection settings shared by every
+// backend implementation, mirroring the options already exposed by
+// olivere's own elastic.ClientOptionFunc set.
+package config
+
+import (
+	"log"
+	"time"
+)
+
+// Config selects a backend and holds the connection settings it needs.
+type Config struct {
+	// Backend selects the implementation: "elastic" or "opensearch".
+	Backend string
+
+	URLs         []string
+	SniffEnabled bool
+
+	BasicAuthUsername string
+	BasicAuthPassword string
+
+	TLSInsecureSkipVerify bool
+
+	HealthcheckInterval time.Duration
+	ErrorLog            *log.Logger
+}
@@ -0,0 +1,164 @@
+package persist
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/olivere/elastic.v3"
+)
+
+// IndexManager owns the lifecycle of a rolling, alias-fronted index
+// family: it ensures a concrete backing index exists with an explicit
+// mapping, points a stable alias at it, and rolls over to a new
+// numbered index once size/age thresholds are hit.
+type IndexManager struct {
+	client *elastic.Client
+	alias  string
+
+	// MaxDocs rolls the write index over once it holds this many docs.
+	MaxDocs int
+	// MaxAge rolls the write index over once it is this old.
+	MaxAge time.Duration
+}
+
+// NewIndexManager builds an IndexManager for alias, using sane rollover
+// defaults callers can override via the exported fields.
+func NewIndexManager(client *elastic.Client, alias string) (*IndexManager, error) {
+	return &IndexManager{
+		client:  client,
+		alias:   alias,
+		MaxDocs: 5000000,
+		MaxAge:  30 * 24 * time.Hour,
+	}, nil
+}
+
+// EnsureIndex makes sure a concrete write index exists behind m.alias,
+// bootstrapping alias-000001 with its mapping on a cold start, or
+// rolling over to the next generation once thresholds are crossed.
+func (m *IndexManager) EnsureIndex() error {
+	writeIndex, err := m.currentWriteIndex()
+	if err != nil {
+		return err
+	}
+	if writeIndex == "" {
+		return m.bootstrap()
+	}
+
+	rollover, err := m.shouldRollover(writeIndex)
+	if err != nil {
+		return err
+	}
+	if rollover {
+		return m.rolloverFrom(writeIndex)
+	}
+	return nil
+}
+
+// currentWriteIndex returns the concrete index m.alias currently points
+// at, or "" if the alias doesn't exist yet.
+func (m *IndexManager) currentWriteIndex() (string, error) {
+	aliases, err := m.client.Aliases().Index("_all").Do()
+	if err != nil {
+		return "", err
+	}
+	indices := aliases.IndicesByAlias(m.alias)
+	if len(indices) == 0 {
+		return "", nil
+	}
+	return indices[0], nil
+}
+
+// bootstrap creates the first backing index, <alias>-000001, with its
+// explicit mapping, and points the alias at it.
+func (m *IndexManager) bootstrap() error {
+	writeIndex := indexName(m.alias, 1)
+
+	if _, err := m.client.CreateIndex(writeIndex).BodyJson(map[string]interface{}{
+		"mappings": ProfileMapping(),
+	}).Do(); err != nil {
+		return err
+	}
+
+	_, err := m.client.Alias().Add(writeIndex, m.alias).Do()
+	return err
+}
+
+// shouldRollover reports whether writeIndex has crossed m.MaxDocs or
+// m.MaxAge.
+func (m *IndexManager) shouldRollover(writeIndex string) (bool, error) {
+	count, err := m.client.Count(writeIndex).Do()
+	if err != nil {
+		return false, err
+	}
+	if m.MaxDocs > 0 && count >= int64(m.MaxDocs) {
+		return true, nil
+	}
+
+	stats, err := m.client.IndexGet(writeIndex).Do()
+	if err != nil {
+		return false, err
+	}
+	info, ok := stats[writeIndex]
+	if !ok {
+		return false, nil
+	}
+	created, _ := info.Settings["index.creation_date"].(string)
+	if created == "" {
+		return false, nil
+	}
+	// index.creation_date is epoch millis, not RFC3339.
+	createdMs, err := strconv.ParseInt(created, 10, 64)
+	if err != nil {
+		return false, nil
+	}
+	createdAt := time.Unix(0, createdMs*int64(time.Millisecond))
+	return m.MaxAge > 0 && time.Since(createdAt) >= m.MaxAge, nil
+}
+
+// rolloverFrom creates the next numbered index with the same mapping
+// and atomically swaps the alias to point at it.
+func (m *IndexManager) rolloverFrom(writeIndex string) error {
+	next := nextIndexName(writeIndex)
+
+	if _, err := m.client.CreateIndex(next).BodyJson(map[string]interface{}{
+		"mappings": ProfileMapping(),
+	}).Do(); err != nil {
+		return err
+	}
+
+	_, err := m.client.Alias().
+		Remove(writeIndex, m.alias).
+		Add(next, m.alias).
+		Do()
+	return err
+}
+
+// indexName formats the nth concrete index behind alias, e.g.
+// indexName("dating_profile", 1) == "dating_profile-000001".
+func indexName(alias string, n int) string {
+	return alias + "-" + pad6(n)
+}
+
+// nextIndexName parses the trailing generation out of writeIndex and
+// formats the next one.
+func nextIndexName(writeIndex string) string {
+	i := strings.LastIndex(writeIndex, "-")
+	if i < 0 {
+		return indexName(writeIndex, 2)
+	}
+	alias := writeIndex[:i]
+	n, err := strconv.Atoi(writeIndex[i+1:])
+	if err != nil {
+		return indexName(writeIndex, 2)
+	}
+	return indexName(alias, n+1)
+}
+
+func pad6(n int) string {
+	s := strconv.Itoa(n)
+	for len(s) < 6 {
+		s = "0" + s
+	}
+	return s
+}
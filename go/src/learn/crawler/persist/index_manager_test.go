@@ -0,0 +1,15 @@
+package persist
+
+import "testing"
+
+func TestNextIndexName(t *testing.T) {
+	cases := map[string]string{
+		"dating_profile-000001": "dating_profile-000002",
+		"dating_profile-000099": "dating_profile-000100",
+	}
+	for in, want := range cases {
+		if got := nextIndexName(in); got != want {
+			t.Errorf("nextIndexName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
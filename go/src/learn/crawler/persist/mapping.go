@@ -0,0 +1,56 @@
+package persist
+
+const (
+	// SuggestField is the document field the completion suggester reads
+	// and writes. What's actually indexed is an engine.Item, which wraps
+	// model.Profile (whose Suggest field is tagged "suggest"; its other
+	// fields serialize with Go field names) in its Payload field, so the
+	// path has to reach
+	// through that envelope rather than naming a top-level property.
+	SuggestField = "Payload.suggest"
+	// SuggestAnalyzer is the analyzer applied to SuggestField.
+	SuggestAnalyzer = "standard"
+)
+
+// ProfileMapping is the explicit mapping applied to every generation of
+// the dating_profile index family: keyword fields for exact matches,
+// text for free-text search, typed numerics for range queries, and a
+// completion field for autocomplete. Field paths mirror the JSON an
+// engine.Item carrying a model.Profile payload actually produces:
+// envelope fields (Url, Id, CrawlerId) are top-level, profile fields
+// live under Payload.
+func ProfileMapping() map[string]interface{} {
+	return map[string]interface{}{
+		"_default_": map[string]interface{}{
+			"properties": profileProperties(),
+		},
+	}
+}
+
+// ProfileProperties is the same property map as ProfileMapping, without
+// the "_default_" mapping-type envelope olivere v3 expects: ES7+ and
+// OpenSearch dropped mapping types, so typeless callers PUT this
+// directly as "mappings".
+func ProfileProperties() map[string]interface{} {
+	return map[string]interface{}{
+		"properties": profileProperties(),
+	}
+}
+
+// profileProperties is the property map ProfileMapping and
+// ProfileProperties both build on.
+func profileProperties() map[string]interface{} {
+	return map[string]interface{}{
+		"Payload.Name":       map[string]interface{}{"type": "text"},
+		"Payload.Occupation": map[string]interface{}{"type": "keyword"},
+		"Payload.Hukou":      map[string]interface{}{"type": "keyword"},
+		"Payload.Age":        map[string]interface{}{"type": "integer"},
+		"Payload.Height":     map[string]interface{}{"type": "integer"},
+		"Payload.Income":     map[string]interface{}{"type": "keyword"},
+		"crawlerId":          map[string]interface{}{"type": "keyword"},
+		SuggestField: map[string]interface{}{
+			"type":     "completion",
+			"analyzer": SuggestAnalyzer,
+		},
+	}
+}
@@ -0,0 +1,13 @@
+// Package dedup provides pluggable "have we crawled this URL before"
+// backends for rpcsupport.DedupService. engine.Scheduler, driven by a
+// rpcsupport.DedupClient, calls Seen and then Mark before dispatching a
+// fetch task, so dedup state lives
+// outside any single worker's memory.
+package dedup
+
+// Backend records and checks whether a URL has already been crawled.
+type Backend interface {
+	Seen(url string) (bool, error)
+	Mark(url string) error
+	Close() error
+}
@@ -0,0 +1,47 @@
+package dedup
+
+import (
+	"gopkg.in/olivere/elastic.v3"
+)
+
+const urlIndex = "crawled_urls"
+
+// ElasticBackend stores seen URLs as documents in a dedicated
+// crawled_urls index, mirroring the PackageExists-style existence check
+// used elsewhere in this codebase against Elasticsearch.
+type ElasticBackend struct {
+	client *elastic.Client
+}
+
+// NewElasticBackend builds an ElasticBackend, creating the crawled_urls
+// index if it doesn't exist yet.
+func NewElasticBackend(client *elastic.Client) (*ElasticBackend, error) {
+	exists, err := client.IndexExists(urlIndex).Do()
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		if _, err := client.CreateIndex(urlIndex).Do(); err != nil {
+			return nil, err
+		}
+	}
+	return &ElasticBackend{client: client}, nil
+}
+
+func (b *ElasticBackend) Seen(url string) (bool, error) {
+	return b.client.Exists().Index(urlIndex).Type("url").Id(url).Do()
+}
+
+func (b *ElasticBackend) Mark(url string) error {
+	_, err := b.client.Index().
+		Index(urlIndex).
+		Type("url").
+		Id(url).
+		BodyJson(map[string]string{"url": url}).
+		Do()
+	return err
+}
+
+func (b *ElasticBackend) Close() error {
+	return nil
+}
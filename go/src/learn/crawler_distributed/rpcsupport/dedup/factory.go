@@ -0,0 +1,31 @@
+package dedup
+
+import (
+	"fmt"
+	"time"
+
+	"gopkg.in/olivere/elastic.v3"
+)
+
+// Options configures backend construction; only the fields relevant to
+// the selected Kind need to be set.
+type Options struct {
+	Kind string // "redis" or "elastic"
+
+	RedisAddr string
+	RedisTTL  time.Duration
+
+	EsClient *elastic.Client
+}
+
+// New builds the Backend selected by opts.Kind.
+func New(opts Options) (Backend, error) {
+	switch opts.Kind {
+	case "redis":
+		return NewRedisBackend(opts.RedisAddr, opts.RedisTTL), nil
+	case "elastic":
+		return NewElasticBackend(opts.EsClient)
+	default:
+		return nil, fmt.Errorf("dedup: unknown backend kind %q", opts.Kind)
+	}
+}
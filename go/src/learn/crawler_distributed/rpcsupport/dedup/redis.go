@@ -0,0 +1,59 @@
+package dedup
+
+import (
+	"time"
+
+	"github.com/garyburd/redigo/redis"
+)
+
+// RedisBackend marks URLs as seen with SETNX, so marking an already
+// marked URL leaves its entry in place, and expires entries after ttl
+// so the dedup set doesn't grow unbounded.
+type RedisBackend struct {
+	pool *redis.Pool
+	ttl  time.Duration
+}
+
+// NewRedisBackend builds a RedisBackend dialing addr on demand.
+func NewRedisBackend(addr string, ttl time.Duration) *RedisBackend {
+	pool := &redis.Pool{
+		MaxIdle:     8,
+		IdleTimeout: 240 * time.Second,
+		Dial: func() (redis.Conn, error) {
+			return redis.Dial("tcp", addr)
+		},
+	}
+	return &RedisBackend{pool: pool, ttl: ttl}
+}
+
+func (b *RedisBackend) Seen(url string) (bool, error) {
+	conn := b.pool.Get()
+	defer conn.Close()
+
+	return redis.Bool(conn.Do("EXISTS", key(url)))
+}
+
+// Mark records url as crawled via SETNX and then, in a separate EXPIRE,
+// sets the entry to expire ttl from now. It does not report whether this
+// call or an earlier one created the entry.
+func (b *RedisBackend) Mark(url string) error {
+	conn := b.pool.Get()
+	defer conn.Close()
+
+	if _, err := conn.Do("SETNX", key(url), 1); err != nil {
+		return err
+	}
+	if b.ttl <= 0 {
+		return nil
+	}
+	_, err := conn.Do("EXPIRE", key(url), int(b.ttl.Seconds()))
+	return err
+}
+
+func (b *RedisBackend) Close() error {
+	return b.pool.Close()
+}
+
+func key(url string) string {
+	return "crawled:" + url
+}
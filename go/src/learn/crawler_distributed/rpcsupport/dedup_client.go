@@ -0,0 +1,43 @@
+package rpcsupport
+
+import (
+	"net/rpc"
+	"net/rpc/jsonrpc"
+
+	"learn/crawler/engine"
+)
+
+// DedupClient is the scheduler-side engine.Deduper, backed by
+// DedupService over the jsonrpc transport RpcServer exposes it on.
+type DedupClient struct {
+	client *rpc.Client
+}
+
+var _ engine.Deduper = (*DedupClient)(nil)
+
+// DialDedupClient connects to a DedupService listening on host.
+func DialDedupClient(host string) (*DedupClient, error) {
+	client, err := jsonrpc.Dial("tcp", host)
+	if err != nil {
+		return nil, err
+	}
+	return &DedupClient{client: client}, nil
+}
+
+// Seen calls DedupService.Seen.
+func (c *DedupClient) Seen(url string) (bool, error) {
+	var seen bool
+	err := c.client.Call("DedupService.Seen", url, &seen)
+	return seen, err
+}
+
+// Mark calls DedupService.Mark.
+func (c *DedupClient) Mark(url string) error {
+	var ok bool
+	return c.client.Call("DedupService.Mark", url, &ok)
+}
+
+// Close closes the underlying connection.
+func (c *DedupClient) Close() error {
+	return c.client.Close()
+}
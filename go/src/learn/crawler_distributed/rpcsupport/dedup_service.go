@@ -0,0 +1,29 @@
+package rpcsupport
+
+import "learn/crawler_distributed/rpcsupport/dedup"
+
+// DedupService exposes a dedup.Backend over the same jsonrpc transport
+// as ItemSaverService, so crawler workers can check and record seen
+// URLs without relying on a single node's in-memory set.
+type DedupService struct {
+	Backend dedup.Backend
+}
+
+// Seen reports whether url has already been marked crawled.
+func (s *DedupService) Seen(url string, reply *bool) error {
+	seen, err := s.Backend.Seen(url)
+	if err != nil {
+		return err
+	}
+	*reply = seen
+	return nil
+}
+
+// Mark records url as crawled.
+func (s *DedupService) Mark(url string, reply *bool) error {
+	if err := s.Backend.Mark(url); err != nil {
+		return err
+	}
+	*reply = true
+	return nil
+}
@@ -0,0 +1,86 @@
+package rpcsupport
+
+import (
+	"context"
+	"io"
+	"net"
+
+	"google.golang.org/grpc"
+
+	"learn/crawler/engine"
+	"learn/crawler/model"
+	"learn/crawler_distributed/itemsaver"
+)
+
+// GrpcItemSaverServer adapts ItemSaverService to the
+// itemsaver.ItemSaverServer interface, so crawler workers can use gRPC
+// instead of jsonrpc for the high-throughput save path.
+type GrpcItemSaverServer struct {
+	Saver *ItemSaverService
+}
+
+func (s *GrpcItemSaverServer) Save(ctx context.Context, in *itemsaver.Item) (*itemsaver.SaveResponse, error) {
+	item := toEngineItem(in)
+
+	id, err := s.Saver.save(item)
+	resp := &itemsaver.SaveResponse{Ok: err == nil, Id: id}
+	if err != nil {
+		resp.Error = err.Error()
+	}
+	return resp, nil
+}
+
+func (s *GrpcItemSaverServer) SaveStream(stream itemsaver.ItemSaver_SaveStreamServer) error {
+	for {
+		in, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		item := toEngineItem(in)
+		id, saveErr := s.Saver.save(item)
+		ack := &itemsaver.SaveAck{Id: id, Ok: saveErr == nil}
+		if saveErr != nil {
+			ack.Error = saveErr.Error()
+		}
+		if err := stream.Send(ack); err != nil {
+			return err
+		}
+	}
+}
+
+// toEngineItem converts the protobuf Item to engine.Item, decoding the
+// Profile oneof branch into model.Profile.
+func toEngineItem(in *itemsaver.Item) engine.Item {
+	item := engine.Item{Url: in.Url, Id: in.Id, Type: in.Type}
+	switch {
+	case in.GetProfile() != nil:
+		p := in.GetProfile()
+		item.Payload = model.Profile{
+			Name:       p.Name,
+			Occupation: p.Occupation,
+			Hukou:      p.Hukou,
+			Age:        int(p.Age),
+			Height:     int(p.Height),
+			Income:     p.Income,
+		}
+	case in.GetRaw() != nil:
+		item.Payload = in.GetRaw()
+	}
+	return item
+}
+
+// GrpcServer starts a gRPC server exposing saver on host.
+func GrpcServer(host string, saver *ItemSaverService) error {
+	listener, err := net.Listen("tcp", host)
+	if err != nil {
+		return err
+	}
+
+	server := grpc.NewServer()
+	itemsaver.RegisterItemSaverServer(server, &GrpcItemSaverServer{Saver: saver})
+	return server.Serve(listener)
+}
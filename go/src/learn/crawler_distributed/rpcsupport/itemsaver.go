@@ -0,0 +1,67 @@
+package rpcsupport
+
+import (
+	"context"
+
+	uuid "github.com/satori/go.uuid"
+
+	"learn/crawler/engine"
+	"learn/crawler/model"
+	"learn/crawler/persist/backend"
+)
+
+// ItemSaverService is the jsonrpc-exposed item saver. It depends only
+// on the backend.Storage interface, so the process behind it can be an
+// olivere v3 client, a plain REST client against a newer cluster, or
+// any other implementation satisfying Storage.
+type ItemSaverService struct {
+	Storage backend.Storage
+
+	// UseUUID, when set, assigns a fresh UUID as the document id
+	// instead of relying on the caller-provided item.Id; the original
+	// id is kept on the document as CrawlerId so it stays searchable.
+	UseUUID bool
+}
+
+// Save enriches item with a suggest field when its payload supports
+// one, then saves it through Storage's async bulk path when available,
+// blocking until that item's own result is known so the RPC call still
+// returns per-item success.
+func (s *ItemSaverService) Save(item engine.Item, reply *bool) error {
+	_, err := s.save(item)
+	*reply = err == nil
+	return err
+}
+
+// save is the shared save path behind Save above and
+// GrpcItemSaverServer, which also needs the id actually written — item
+// mutations here (e.g. the UUID swap below) don't reach a caller who
+// passed item by value, so save reports it back explicitly.
+func (s *ItemSaverService) save(item engine.Item) (string, error) {
+	item = withSuggest(item)
+	if s.UseUUID {
+		item.CrawlerId = item.Id
+		item.Id = uuid.NewV4().String()
+	}
+
+	var err error
+	if async, ok := s.Storage.(backend.AsyncBulkStorage); ok {
+		done := make(chan error, 1)
+		async.SaveAsync(item, func(e error) { done <- e })
+		err = <-done
+	} else {
+		_, err = s.Storage.Save(context.Background(), item)
+	}
+
+	return item.Id, err
+}
+
+// withSuggest enriches item with a completion-suggester field when its
+// payload knows how to build one (currently only model.Profile).
+func withSuggest(item engine.Item) engine.Item {
+	if profile, ok := item.Payload.(model.Profile); ok {
+		profile.Suggest = profile.BuildSuggest(item.Url, item.Id)
+		item.Payload = profile
+	}
+	return item
+}
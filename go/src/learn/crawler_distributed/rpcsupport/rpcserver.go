@@ -0,0 +1,33 @@
+package rpcsupport
+
+import (
+	"net"
+	"net/rpc"
+	"net/rpc/jsonrpc"
+
+	"github.com/gpmgo/gopm/modules/log"
+)
+
+// RpcServer registers each of services for RPC and serves them over
+// jsonrpc on host.
+func RpcServer(host string, services ...interface{}) error {
+	for _, service := range services {
+		if err := rpc.Register(service); err != nil {
+			return err
+		}
+	}
+
+	listener, err := net.Listen("tcp", host)
+	if err != nil {
+		return err
+	}
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			log.Error("accept error: %v", err)
+			continue
+		}
+		go jsonrpc.ServeConn(conn)
+	}
+}
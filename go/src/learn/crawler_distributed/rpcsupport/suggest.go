@@ -0,0 +1,75 @@
+package rpcsupport
+
+import (
+	"encoding/json"
+
+	"gopkg.in/olivere/elastic.v3"
+
+	"learn/crawler/persist"
+)
+
+// SuggestService answers autocomplete queries against the completion
+// field persist.IndexManager installs on the profile mapping.
+type SuggestService struct {
+	Client *elastic.Client
+	Index  string
+}
+
+// NewSuggestService builds a SuggestService, ensuring its backing
+// index/mapping exists via persist.IndexManager.
+func NewSuggestService(client *elastic.Client, index string) (*SuggestService, error) {
+	manager, err := persist.NewIndexManager(client, index)
+	if err != nil {
+		return nil, err
+	}
+	if err := manager.EnsureIndex(); err != nil {
+		return nil, err
+	}
+	return &SuggestService{Client: client, Index: index}, nil
+}
+
+// Suggestion is one decoded completion-suggester option.
+type Suggestion struct {
+	Text string
+	Url  string
+	Id   string
+}
+
+// SuggestArgs are the RPC arguments for Suggest.
+type SuggestArgs struct {
+	Prefix string
+	Size   int
+}
+
+// Suggest returns up to args.Size fuzzy completions for args.Prefix.
+func (s *SuggestService) Suggest(args SuggestArgs, reply *[]Suggestion) error {
+	suggester := elastic.NewCompletionSuggester("profile-suggest").
+		Field(persist.SuggestField).
+		Text(args.Prefix).
+		Size(args.Size).
+		Fuzziness(elastic.NewFuzziness(1))
+
+	result, err := s.Client.Search(s.Index).Suggester(suggester).Do()
+	if err != nil {
+		return err
+	}
+
+	options := []Suggestion{}
+	for _, group := range result.Suggest["profile-suggest"] {
+		for _, option := range group.Options {
+			sugg := Suggestion{Text: option.Text}
+			if option.Payload != nil {
+				var payload struct {
+					Url string `json:"url"`
+					Id  string `json:"id"`
+				}
+				if err := json.Unmarshal(*option.Payload, &payload); err == nil {
+					sugg.Url, sugg.Id = payload.Url, payload.Id
+				}
+			}
+			options = append(options, sugg)
+		}
+	}
+	*reply = options
+	return nil
+}
@@ -1,32 +1,108 @@
 package main
 
 import (
+	"flag"
+	"time"
+
 	"github.com/gpmgo/gopm/modules/log"
 	"gopkg.in/olivere/elastic.v3"
+	"learn/crawler/persist/backend"
+	"learn/crawler/persist/config"
 	"learn/crawler_distributed/rpcsupport"
+	"learn/crawler_distributed/rpcsupport/dedup"
 )
 
 const profileDatabase = "dating_profile"
 
+var (
+	transport      = flag.String("transport", "jsonrpc", "rpc transport: jsonrpc|grpc")
+	storageBackend = flag.String("storage-backend", "elastic", "item storage backend: elastic|opensearch")
+	esURL          = flag.String("es-url", "http://127.0.0.1:9200", "elasticsearch/opensearch URL")
+	useUUID        = flag.Bool("use-uuid", false, "assign UUID doc ids instead of the crawler-provided id")
+
+	dedupBackend = flag.String("dedup-backend", "redis", "dedup backend: redis|elastic")
+	redisAddr    = flag.String("redis-addr", "127.0.0.1:6379", "redis address for the redis dedup backend")
+)
+
 func main() {
+	flag.Parse()
+
+	var err error
+	switch *transport {
+	case "grpc":
+		err = serveGrpc(":1234", profileDatabase)
+	default:
+		err = serveJsonRpc(":1234", profileDatabase)
+	}
+	if err != nil {
+		log.Fatal("rpc server stopped: %v", err)
+	}
+}
+
+// newStorage builds the item Storage backend shared by both transports.
+func newStorage(index string) (backend.Storage, error) {
+	return backend.New(config.Config{
+		Backend:      *storageBackend,
+		URLs:         []string{*esURL},
+		SniffEnabled: false,
+	}, index)
+}
+
+// serveGrpc starts the gRPC item-saver transport on host.
+func serveGrpc(host, index string) error {
+	storage, err := newStorage(index)
+	if err != nil {
+		return err
+	}
+	defer storage.Close()
+
+	itemSaverService := &rpcsupport.ItemSaverService{
+		Storage: storage,
+		UseUUID: *useUUID,
+	}
 
-	serverRpc(":1234", profileDatabase)
+	return rpcsupport.GrpcServer(host, itemSaverService)
 }
 
-func serverRpc(host, index string) error {
+// serveJsonRpc starts the jsonrpc transport, which also carries
+// Suggest and Dedup alongside item saving.
+func serveJsonRpc(host, index string) error {
+	storage, err := newStorage(index)
+	if err != nil {
+		return err
+	}
+	defer storage.Close()
+
+	itemSaverService := &rpcsupport.ItemSaverService{
+		Storage: storage,
+		UseUUID: *useUUID,
+	}
 
+	// Suggest and elastic-backed dedup are Elasticsearch-only
+	// extensions layered on top of the pluggable item Storage above,
+	// so they still talk to an olivere client directly.
 	client, err := elastic.NewClient(
+		elastic.SetURL(*esURL),
 		elastic.SetSniff(false))
-
 	if err != nil {
-		log.Fatal("start elasticSearch fail %v", err)
+		return err
 	}
 
-	itemSaverService := rpcsupport.ItemSaverService{
-		Client: client,
-		Index:  index,
+	suggestService, err := rpcsupport.NewSuggestService(client, index)
+	if err != nil {
+		return err
 	}
 
-	return rpcsupport.RpcServer(host, itemSaverService)
+	dedupBackendImpl, err := dedup.New(dedup.Options{
+		Kind:      *dedupBackend,
+		RedisAddr: *redisAddr,
+		RedisTTL:  7 * 24 * time.Hour,
+		EsClient:  client,
+	})
+	if err != nil {
+		return err
+	}
+	dedupService := &rpcsupport.DedupService{Backend: dedupBackendImpl}
 
+	return rpcsupport.RpcServer(host, itemSaverService, suggestService, dedupService)
 }